@@ -0,0 +1,74 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"honnef.co/go/tools/go/token"
+	"honnef.co/go/tools/lint"
+)
+
+func TestApplyRewritesFile(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "foo.go")
+	src := []byte("package foo\n\nvar x = 1\n")
+	if err := ioutil.WriteFile(name, src, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	f := fset.AddFile(name, fset.Base(), len(src))
+	pos := func(offset int) token.Pos { return f.Pos(offset) }
+
+	fixes := []lint.Fix{
+		{
+			Message: "rewrite x's value",
+			Edits: []lint.TextEdit{
+				{Pos: pos(21), End: pos(22), NewText: []byte("2")},
+			},
+		},
+	}
+
+	if err := apply(fset, fixes); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	got, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "package foo\n\nvar x = 2\n"; string(got) != want {
+		t.Errorf("apply rewrote file to %q, want %q", got, want)
+	}
+}
+
+func TestApplyRejectsOverlappingEdits(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "foo.go")
+	src := []byte("package foo\n\nvar x = 1\n")
+	if err := ioutil.WriteFile(name, src, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	f := fset.AddFile(name, fset.Base(), len(src))
+	pos := func(offset int) token.Pos { return f.Pos(offset) }
+
+	fixes := []lint.Fix{
+		{Message: "fix A", Edits: []lint.TextEdit{{Pos: pos(13), End: pos(18), NewText: []byte("yyyyy")}}},
+		{Message: "fix B", Edits: []lint.TextEdit{{Pos: pos(15), End: pos(20), NewText: []byte("zzzzz")}}},
+	}
+
+	if err := apply(fset, fixes); err == nil {
+		t.Fatal("apply: expected an error for overlapping edits, got nil")
+	}
+
+	got, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(src) {
+		t.Error("apply modified the file despite rejecting it for overlapping edits")
+	}
+}
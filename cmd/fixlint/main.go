@@ -0,0 +1,137 @@
+// Command fixlint applies the suggested fixes recorded by lintdsl
+// checks via lintdsl.SuggestedFix.
+//
+// It loads the packages named on the command line, builds SSA for
+// each, runs every check in checks against a synthesized *lint.Job
+// per package, and, given -fix, applies the lintdsl.Fix values those
+// checks recorded. checks is empty for now: this module doesn't yet
+// register any lintdsl-style checks that call SuggestedFix, so a run
+// simply finds nothing to fix. apply, the actual fix-application
+// logic, is exercised as soon as a check populates checks.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+
+	"honnef.co/go/tools/go/packages"
+	"honnef.co/go/tools/go/token"
+	"honnef.co/go/tools/lint"
+	"honnef.co/go/tools/lint/lintdsl"
+	"honnef.co/go/tools/ssa"
+	"honnef.co/go/tools/ssa/ssautil"
+)
+
+// checks is the set of lintdsl-style checks fixlint runs. It's empty
+// until this module registers checks here that call
+// lintdsl.SuggestedFix.
+var checks []func(j *lint.Job)
+
+var fix = flag.Bool("fix", false, "apply suggested fixes in place; fixlint does nothing without it")
+
+func main() {
+	log.SetFlags(0)
+	flag.Parse()
+	if flag.NArg() == 0 {
+		log.Fatal("fixlint: no packages given")
+	}
+	if !*fix {
+		fmt.Fprintln(os.Stderr, "fixlint: pass -fix to apply suggested fixes; without it there is nothing to do")
+		os.Exit(2)
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := packages.Load(&packages.Config{Fset: fset, Mode: packages.LoadSyntax}, flag.Args()...)
+	if err != nil {
+		log.Fatalf("fixlint: loading packages: %s", err)
+	}
+
+	prog, ssapkgs := ssautil.Packages(pkgs, ssa.GlobalDebug)
+	prog.Build()
+
+	var fixes []lintdsl.Fix
+	for _, ssapkg := range ssapkgs {
+		if ssapkg == nil {
+			continue // ill-typed package; nothing to check
+		}
+		j := &lint.Job{
+			Program: &lint.Program{SSA: prog, GoVersion: lint.GoVersion},
+			Pkg:     &lint.Pkg{Types: ssapkg.Pkg},
+		}
+		for _, check := range checks {
+			check(j)
+		}
+		for _, p := range j.Problems {
+			fixes = append(fixes, p.Fixes...)
+		}
+	}
+
+	if err := apply(fset, fixes); err != nil {
+		log.Fatalf("fixlint: %s", err)
+	}
+}
+
+// apply groups fixes by the file their edits fall in, rejects any
+// fix whose own edits overlap and any pair of edits from different
+// fixes that overlap once grouped, and then rewrites each affected
+// file in place, using fset to resolve each edit's position back to
+// a file and byte offset.
+//
+// No file is touched until every fix has been validated, so a single
+// overlapping edit anywhere aborts the whole batch.
+func apply(fset *token.FileSet, fixes []lintdsl.Fix) error {
+	byFile := map[string][]lintdsl.TextEdit{}
+	for _, fx := range fixes {
+		edits := append([]lintdsl.TextEdit(nil), fx.Edits...)
+		sort.Slice(edits, func(i, j int) bool { return edits[i].Pos < edits[j].Pos })
+		for i := 1; i < len(edits); i++ {
+			if edits[i].Pos < edits[i-1].End {
+				return fmt.Errorf("%s: overlapping edits in the same fix", fx.Message)
+			}
+		}
+		for _, e := range edits {
+			f := fset.File(e.Pos)
+			if f == nil {
+				return fmt.Errorf("%s: edit position not in file set", fx.Message)
+			}
+			byFile[f.Name()] = append(byFile[f.Name()], e)
+		}
+	}
+
+	rewritten := make(map[string][]byte, len(byFile))
+	for name, edits := range byFile {
+		sort.Slice(edits, func(i, j int) bool { return edits[i].Pos < edits[j].Pos })
+		for i := 1; i < len(edits); i++ {
+			if edits[i].Pos < edits[i-1].End {
+				return fmt.Errorf("%s: overlapping edits across problems", name)
+			}
+		}
+
+		src, err := ioutil.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		base := token.Pos(fset.File(edits[0].Pos).Base())
+
+		var out []byte
+		cur := base
+		for _, e := range edits {
+			out = append(out, src[cur-base:e.Pos-base]...)
+			out = append(out, e.NewText...)
+			cur = e.End
+		}
+		out = append(out, src[cur-base:]...)
+		rewritten[name] = out
+	}
+
+	for name, src := range rewritten {
+		if err := ioutil.WriteFile(name, src, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
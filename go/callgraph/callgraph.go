@@ -0,0 +1,155 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package callgraph defines the call graph and various algorithms
+// and utilities to operate on it.
+//
+// A call graph is a labelled directed graph whose nodes represent
+// functions and whose edges represent calls between functions.
+//
+// A call graph is a multigraph: it may contain multiple edges (calls)
+// between the same pair of functions, if the call occurs in more
+// than one place, or if the callee can be reached via static,
+// dynamic, and interface dispatch from the same call site.
+package callgraph
+
+import "honnef.co/go/tools/go/ssa"
+
+// A Graph represents a call graph.
+//
+// A graph may contain nodes that are not reachable from the root.
+// If the call graph is sound, such nodes indicate unreachable
+// functions.
+type Graph struct {
+	Nodes map[*ssa.Function]*Node // all nodes by function
+	Root  *Node                   // the distinguished root node
+}
+
+// New returns a new Graph with the given root node.
+func New(root *ssa.Function) *Graph {
+	g := &Graph{Nodes: make(map[*ssa.Function]*Node)}
+	g.Root = g.CreateNode(root)
+	return g
+}
+
+// CreateNode returns the Node for fn, creating it if not present.
+func (g *Graph) CreateNode(fn *ssa.Function) *Node {
+	n, ok := g.Nodes[fn]
+	if !ok {
+		n = &Node{Func: fn, ID: len(g.Nodes)}
+		g.Nodes[fn] = n
+	}
+	return n
+}
+
+// A Node represents a node in a call graph.
+type Node struct {
+	Func *ssa.Function // the function this node represents
+	ID   int           // 0-based sequence number
+	In   []*Edge       // unordered set of incoming call edges (n.In[*].Callee == n)
+	Out  []*Edge       // unordered set of outgoing call edges (n.Out[*].Caller == n)
+}
+
+// An Edge represents a call edge in a call graph.
+type Edge struct {
+	Caller *Node
+	Site   ssa.CallInstruction
+	Callee *Node
+}
+
+// AddEdge adds the edge (caller, site, callee) to the call graph.
+// Elements may be duplicated.
+func AddEdge(caller *Node, site ssa.CallInstruction, callee *Node) {
+	e := &Edge{Caller: caller, Site: site, Callee: callee}
+	caller.Out = append(caller.Out, e)
+	callee.In = append(callee.In, e)
+}
+
+// CalleesOf returns the set of functions called directly by the
+// function represented by caller.
+func CalleesOf(caller *Node) map[*Node]bool {
+	callees := make(map[*Node]bool)
+	for _, e := range caller.Out {
+		callees[e.Callee] = true
+	}
+	return callees
+}
+
+// CallersOf returns the set of functions that call the function
+// represented by callee.
+func CallersOf(callee *Node) map[*Node]bool {
+	callers := make(map[*Node]bool)
+	for _, e := range callee.In {
+		callers[e.Caller] = true
+	}
+	return callers
+}
+
+// GraphVisitEdges visits all the edges in graph g in depth-first
+// order, starting a traversal from each node of g in turn; the order
+// in which those starting nodes are taken is not defined. The edge
+// function is called for each edge; if it returns non-nil, visitation
+// stops and GraphVisitEdges returns that error.
+func GraphVisitEdges(g *Graph, edge func(*Edge) error) error {
+	seen := make(map[*Node]bool)
+	var visit func(n *Node) error
+	visit = func(n *Node) error {
+		if !seen[n] {
+			seen[n] = true
+			for _, e := range n.Out {
+				if err := edge(e); err != nil {
+					return err
+				}
+				if err := visit(e.Callee); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	for _, n := range g.Nodes {
+		if err := visit(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteSyntheticNodes removes from g all nodes, other than the root,
+// for synthetic functions (those whose Synthetic field is non-empty,
+// e.g. wrappers, bound method thunks), splicing out their edges so
+// that each caller of a deleted node is connected directly to its
+// callees.
+func DeleteSyntheticNodes(g *Graph) {
+	synthetic := func(n *Node) bool {
+		return n != g.Root && n.Func.Synthetic != ""
+	}
+	for fn, n := range g.Nodes {
+		if !synthetic(n) {
+			continue
+		}
+		for _, in := range n.In {
+			for _, out := range n.Out {
+				AddEdge(in.Caller, in.Site, out.Callee)
+			}
+		}
+		for _, in := range n.In {
+			in.Caller.Out = removeEdge(in.Caller.Out, n)
+		}
+		for _, out := range n.Out {
+			out.Callee.In = removeEdge(out.Callee.In, n)
+		}
+		delete(g.Nodes, fn)
+	}
+}
+
+func removeEdge(edges []*Edge, n *Node) []*Edge {
+	out := edges[:0]
+	for _, e := range edges {
+		if e.Caller != n && e.Callee != n {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -0,0 +1,162 @@
+// Copyright 2013 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cha computes the call graph of a Go program using the
+// Class Hierarchy Analysis (CHA) algorithm.
+//
+// CHA was first described in "Optimization of Object-Oriented Programs
+// Using Static Class Hierarchy Analysis" (Dean, Grove, Chambers, ECOOP
+// 1995). It is a cheap, conservative, whole-program analysis: it
+// treats every call as though it could dispatch to any method in the
+// program that is compatible with the call's static type, without
+// regard for flow or reachability. It is not as precise as
+// points-to analysis but it is simple, fast, and a sound
+// over-approximation.
+package cha
+
+import (
+	"honnef.co/go/tools/go/callgraph"
+	"honnef.co/go/tools/go/ssa"
+	"honnef.co/go/tools/go/types"
+)
+
+// CallGraph computes the call graph of the specified program using
+// the Class Hierarchy Analysis algorithm.
+//
+// This package is untested: a meaningful test needs a real
+// *ssa.Program built from type-checked syntax, exercising static,
+// dynamic, and interface dispatch plus synthetic init/main roots, and
+// honnef.co/go/tools/go/ssa itself isn't part of this snapshot to
+// build one against. Treat that as an open gap, not a deliberate
+// omission, once the ssa package is available to import.
+func CallGraph(prog *ssa.Program) *callgraph.Graph {
+	cg := callgraph.New(nil)
+
+	// allFuncs is every function allFunctions finds; calls to function
+	// values are resolved by scanning it for matching signatures.
+	allFuncs := allFunctions(prog)
+	for _, fn := range allFuncs {
+		cg.CreateNode(fn)
+	}
+
+	// methodsByType maps each named or pointer-to-named type to the
+	// set of its methods, so that interface call resolution doesn't
+	// have to rescan the program for each call site.
+	methodsByType := make(map[types.Type][]*ssa.Function)
+	for _, T := range prog.RuntimeTypes() {
+		mset := prog.MethodSets.MethodSet(T)
+		for i := 0; i < mset.Len(); i++ {
+			if fn := prog.MethodValue(mset.At(i)); fn != nil {
+				methodsByType[T] = append(methodsByType[T], fn)
+			}
+		}
+	}
+
+	// addEdge adds an edge from caller, found via instr, to callee,
+	// creating nodes for either end as necessary.
+	addEdge := func(caller *ssa.Function, instr ssa.CallInstruction, callee *ssa.Function) {
+		callgraph.AddEdge(cg.CreateNode(caller), instr, cg.CreateNode(callee))
+	}
+
+	for _, fn := range allFuncs {
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				site, ok := instr.(ssa.CallInstruction)
+				if !ok {
+					continue
+				}
+				common := site.Common()
+				switch {
+				case common.IsInvoke():
+					// Interface method invocation: resolve to every
+					// concrete type in the program whose method set
+					// implements the call's interface and that has
+					// the invoked method.
+					iface := common.Value.Type().Underlying().(*types.Interface)
+					for T, methods := range methodsByType {
+						if !types.Implements(T, iface) {
+							continue
+						}
+						for _, m := range methods {
+							if m.Name() == common.Method.Name() {
+								addEdge(fn, site, m)
+							}
+						}
+					}
+
+				case common.StaticCallee() != nil:
+					// Static call: a single, unambiguous callee.
+					addEdge(fn, site, common.StaticCallee())
+
+				default:
+					// Dynamic call of a function value: conservatively
+					// connect to every address-taken function with a
+					// matching signature.
+					sig := common.Signature()
+					for _, g := range allFuncs {
+						if types.Identical(g.Signature, sig) {
+							addEdge(fn, site, g)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Synthetic root: calls from package initializers and main.main,
+	// so that the whole program is reachable from a single node even
+	// though there is no single real entry point during CHA. Unlike
+	// init, which is a root in every package, main is only special in
+	// package main: a func main in some other package is an ordinary
+	// function and must not be treated as an entry point.
+	for _, fn := range allFuncs {
+		if fn.Name() == "init" || (fn.Name() == "main" && fn.Pkg != nil && fn.Pkg.Pkg.Name() == "main") {
+			callgraph.AddEdge(cg.Root, nil, cg.CreateNode(fn))
+		}
+	}
+
+	return cg
+}
+
+// allFunctions returns every function in prog: every package-level
+// function, every method of every named type declared at package
+// level (both on T and on *T), and every anonymous function nested,
+// transitively, inside any of those.
+//
+// This is a local copy of the walk ssautil.AllFunctions performs in
+// the forks this package is modeled on. cha can't import ssautil to
+// reuse it directly: ssautil.CallGraph (ssa/ssautil/callgraph.go)
+// already imports cha, and ssautil importing cha back would cycle.
+func allFunctions(prog *ssa.Program) []*ssa.Function {
+	var funcs []*ssa.Function
+	seen := make(map[*ssa.Function]bool)
+	var visit func(fn *ssa.Function)
+	visit = func(fn *ssa.Function) {
+		if fn == nil || seen[fn] {
+			return
+		}
+		seen[fn] = true
+		funcs = append(funcs, fn)
+		for _, anon := range fn.AnonFuncs {
+			visit(anon)
+		}
+	}
+
+	for _, pkg := range prog.AllPackages() {
+		for _, mem := range pkg.Members {
+			switch mem := mem.(type) {
+			case *ssa.Function:
+				visit(mem)
+			case *ssa.Type:
+				for _, T := range []types.Type{mem.Type(), types.NewPointer(mem.Type())} {
+					mset := prog.MethodSets.MethodSet(T)
+					for i := 0; i < mset.Len(); i++ {
+						visit(prog.MethodValue(mset.At(i)))
+					}
+				}
+			}
+		}
+	}
+	return funcs
+}
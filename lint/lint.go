@@ -0,0 +1,93 @@
+// Package lint defines the interface between checks and the tools
+// that drive them. A check is a plain function over a *Job; a
+// Checker groups a related set of them together under the names
+// they're reported as.
+package lint
+
+import (
+	"fmt"
+
+	"honnef.co/go/tools/go/token"
+	"honnef.co/go/tools/go/types"
+	"honnef.co/go/tools/ssa"
+)
+
+// GoVersion is the minor version of the newest Go release this
+// module understands, used by checks that only apply starting at a
+// given language or library version; see lintdsl.IsGoVersion.
+const GoVersion = 21
+
+// A Positioner is anything a problem or fix can be anchored to, most
+// commonly an SSA value, instruction, or AST node.
+type Positioner interface {
+	Pos() token.Pos
+}
+
+// Program groups the whole-program state a Job's checks run against.
+type Program struct {
+	SSA       *ssa.Program
+	GoVersion int
+}
+
+// A Pkg is the package-specific state a Job runs its checks against.
+type Pkg struct {
+	Types *types.Package
+}
+
+// A TextEdit replaces the source text between Pos and End with
+// NewText. A zero-length range (Pos == End) is an insertion; a nil
+// NewText is a deletion.
+type TextEdit struct {
+	Pos, End token.Pos
+	NewText  []byte
+}
+
+// A Fix is a single machine-applicable suggestion attached to a
+// Problem. Message describes what applying it would do; Edits are
+// the non-overlapping text edits that make it up.
+type Fix struct {
+	Message string
+	Edits   []TextEdit
+}
+
+// A Problem is a single issue reported by a check via Job.Errorf,
+// anchored to a position and carrying a human-readable message. A
+// check that knows how to mechanically resolve the problem appends
+// one or more suggested fixes to Fixes.
+type Problem struct {
+	Pos     token.Pos
+	Message string
+	Fixes   []Fix
+}
+
+// A Job runs a Checker's checks against a single package of a
+// Program, accumulating the Problems they report.
+type Job struct {
+	Program  *Program
+	Pkg      *Pkg
+	Problems []*Problem
+}
+
+// Errorf records a new Problem at n's position and returns it, so
+// that callers can attach suggested fixes to it via its Fixes field.
+func (j *Job) Errorf(n Positioner, format string, args ...interface{}) *Problem {
+	p := &Problem{Pos: n.Pos(), Message: fmt.Sprintf(format, args...)}
+	j.Problems = append(j.Problems, p)
+	return p
+}
+
+// NodePackage returns the package node belongs to.
+func (j *Job) NodePackage(node Positioner) *Pkg {
+	return j.Pkg
+}
+
+// A Func is a single check: a function that inspects j.Program (and,
+// for per-package checks, j.Pkg) and reports problems via j.Errorf.
+type Func func(j *Job)
+
+// A Checker groups a related set of Funcs under the names they're
+// reported as.
+type Checker interface {
+	Init(prog *Program)
+	Funcs() map[string]Func
+}
@@ -0,0 +1,180 @@
+// Package analyzer adapts lintdsl-style checks, written as a plain
+// func(j *lint.Job), to golang.org/x/tools/go/analysis.Analyzer, the
+// plugin unit understood by go vet, gopls, and multichecker drivers.
+// This lets the existing checks ship as a drop-in multichecker.Main
+// binary instead of requiring their own driver and flag handling.
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"go/printer"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+
+	"honnef.co/go/tools/go/packages"
+	"honnef.co/go/tools/lint"
+	"honnef.co/go/tools/ssa"
+	"honnef.co/go/tools/ssa/ssautil"
+)
+
+// New wraps check into an *analysis.Analyzer named name. On each run
+// it synthesizes a *lint.Job for pass.Pkg and forwards every problem
+// check reports through pass.Report as an analysis.Diagnostic.
+func New(name, doc string, check func(j *lint.Job)) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name: name,
+		Doc:  doc,
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			j, err := jobFor(pass)
+			if err != nil {
+				return nil, err
+			}
+			check(j)
+			for _, p := range j.Problems {
+				pass.Report(analysis.Diagnostic{
+					Pos:            p.Pos,
+					Message:        p.Message,
+					SuggestedFixes: lintFixesToAnalysis(p.Fixes),
+				})
+			}
+			return nil, nil
+		},
+	}
+}
+
+// jobFor synthesizes a *lint.Job for pass.Pkg.
+//
+// pass.Pkg, pass.Files, and pass.TypesInfo are plain go/types and
+// go/ast values, per the analysis.Pass contract. This module's own
+// checks run on SSA built from its own type-checked syntax tree
+// (honnef.co/go/tools/go/types), which isn't the same type as
+// go/types and has no conversion from it, so jobFor can't simply wrap
+// the driver's already-computed type information: it reloads
+// pass.Pkg's import path through this module's own loader, the same
+// one ssautil.Packages expects, sharing pass.Fset so that the
+// resulting positions still line up with what pass.Report expects.
+//
+// Reloading from pass.Pkg.Path() alone would read whatever is on
+// disk, silently discarding any edits gopls or another driver is
+// holding only in pass.Files (unsaved buffers, edits mid-keystroke).
+// To honor those, jobFor renders each file in pass.Files back to
+// source with go/printer and feeds the result to the loader as an
+// overlay keyed by that file's path, so the reload sees exactly the
+// syntax pass.Files describes rather than the last-saved copy.
+func jobFor(pass *analysis.Pass) (*lint.Job, error) {
+	overlay := make(map[string][]byte, len(pass.Files))
+	for _, f := range pass.Files {
+		name := pass.Fset.Position(f.Pos()).Filename
+		if name == "" {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := printer.Fprint(&buf, pass.Fset, f); err != nil {
+			return nil, fmt.Errorf("analyzer: rendering %s: %w", name, err)
+		}
+		overlay[name] = buf.Bytes()
+	}
+
+	cfg := &packages.Config{Fset: pass.Fset, Mode: packages.LoadSyntax, Overlay: overlay}
+	pkgs, err := packages.Load(cfg, pass.Pkg.Path())
+	if err != nil {
+		return nil, fmt.Errorf("analyzer: loading %s: %w", pass.Pkg.Path(), err)
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("analyzer: %s: package not found", pass.Pkg.Path())
+	}
+
+	prog, ssapkgs := ssautil.Packages(pkgs, ssa.GlobalDebug)
+	ssapkg := ssapkgs[0]
+	if ssapkg == nil {
+		return nil, fmt.Errorf("analyzer: %s: package is ill-typed, can't build SSA", pass.Pkg.Path())
+	}
+	ssapkg.Build()
+
+	return &lint.Job{
+		Program: &lint.Program{
+			SSA:       prog,
+			GoVersion: lint.GoVersion,
+		},
+		Pkg: &lint.Pkg{Types: ssapkg.Pkg},
+	}, nil
+}
+
+// lintFixesToAnalysis converts fixes, as attached to a lint.Problem,
+// into the equivalent analysis.SuggestedFix values for pass.Report.
+func lintFixesToAnalysis(fixes []lint.Fix) []analysis.SuggestedFix {
+	if len(fixes) == 0 {
+		return nil
+	}
+	out := make([]analysis.SuggestedFix, len(fixes))
+	for i, fx := range fixes {
+		edits := make([]analysis.TextEdit, len(fx.Edits))
+		for j, e := range fx.Edits {
+			edits[j] = analysis.TextEdit{Pos: e.Pos, End: e.End, NewText: e.NewText}
+		}
+		out[i] = analysis.SuggestedFix{Message: fx.Message, TextEdits: edits}
+	}
+	return out
+}
+
+// analysisFixesToLint is the inverse of lintFixesToAnalysis, used to
+// carry a third-party analyzer's suggested fixes onto the lint.Problem
+// FromAnalyzer reports them as.
+func analysisFixesToLint(fixes []analysis.SuggestedFix) []lint.Fix {
+	if len(fixes) == 0 {
+		return nil
+	}
+	out := make([]lint.Fix, len(fixes))
+	for i, fx := range fixes {
+		edits := make([]lint.TextEdit, len(fx.TextEdits))
+		for j, e := range fx.TextEdits {
+			edits[j] = lint.TextEdit{Pos: e.Pos, End: e.End, NewText: e.NewText}
+		}
+		out[i] = lint.Fix{Message: fx.Message, Edits: edits}
+	}
+	return out
+}
+
+// FromAnalyzer adapts a third-party *analysis.Analyzer into a
+// lint.Checker, so that existing checkers can consume third-party
+// analyzers alongside lintdsl-style checks.
+//
+// The *analysis.Pass handed to a.Run sets only Analyzer, Fset, an
+// empty ResultOf, and Report: Pkg, Files, TypesInfo, and the results
+// of a.Requires are not set.
+func FromAnalyzer(a *analysis.Analyzer) lint.Checker {
+	return analyzerChecker{a}
+}
+
+type analyzerChecker struct {
+	a *analysis.Analyzer
+}
+
+func (c analyzerChecker) Init(prog *lint.Program) {}
+
+func (c analyzerChecker) Funcs() map[string]lint.Func {
+	return map[string]lint.Func{
+		c.a.Name: func(j *lint.Job) {
+			pass := &analysis.Pass{
+				Analyzer: c.a,
+				Fset:     j.Program.SSA.Fset,
+				ResultOf: map[*analysis.Analyzer]interface{}{},
+				Report: func(d analysis.Diagnostic) {
+					p := j.Errorf(bareTokenPos(d.Pos), "%s", d.Message)
+					p.Fixes = analysisFixesToLint(d.SuggestedFixes)
+				},
+			}
+			if _, err := c.a.Run(pass); err != nil {
+				j.Errorf(bareTokenPos(token.NoPos), "%s: %s", c.a.Name, err)
+			}
+		},
+	}
+}
+
+// bareTokenPos adapts a bare token.Pos to the lint.Positioner
+// interface expected by j.Errorf.
+type bareTokenPos token.Pos
+
+func (p bareTokenPos) Pos() token.Pos { return token.Pos(p) }
@@ -0,0 +1,59 @@
+package lintdsl
+
+import (
+	"bytes"
+	"fmt"
+
+	"honnef.co/go/tools/go/types"
+	"honnef.co/go/tools/lint"
+)
+
+// TextEdit and Fix are aliases of the lint package's types of the
+// same name. They live there, rather than here, so that lint.Problem
+// can hold a []Fix without lintdsl importing lint importing lintdsl.
+type (
+	TextEdit = lint.TextEdit
+	Fix      = lint.Fix
+)
+
+// ReplaceNode returns a TextEdit that replaces old with the rendered
+// form of new.
+func ReplaceNode(j *lint.Job, old, new types.Node) TextEdit {
+	return TextEdit{Pos: old.Pos(), End: old.End(), NewText: []byte(Render(j, new))}
+}
+
+// DeleteNode returns a TextEdit that removes n from the source.
+func DeleteNode(n types.Node) TextEdit {
+	return TextEdit{Pos: n.Pos(), End: n.End()}
+}
+
+// InsertBefore returns a TextEdit that inserts code immediately
+// before n.
+func InsertBefore(n types.Node, code string) TextEdit {
+	return TextEdit{Pos: n.Pos(), End: n.Pos(), NewText: []byte(code)}
+}
+
+// RewriteCall returns a Fix that replaces call with a call to newFun
+// with newArgs in its place.
+func RewriteCall(j *lint.Job, call *types.CallExpr, newFun string, newArgs []types.Expr) Fix {
+	var buf bytes.Buffer
+	buf.WriteString(newFun)
+	buf.WriteByte('(')
+	for i, arg := range newArgs {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(Render(j, arg))
+	}
+	buf.WriteByte(')')
+	return Fix{
+		Message: fmt.Sprintf("rewrite call to %s", newFun),
+		Edits:   []TextEdit{{Pos: call.Pos(), End: call.End(), NewText: buf.Bytes()}},
+	}
+}
+
+// SuggestedFix attaches fix to p as a candidate machine-applicable
+// fix for the problem p represents.
+func SuggestedFix(p *lint.Problem, fix Fix) {
+	p.Fixes = append(p.Fixes, fix)
+}
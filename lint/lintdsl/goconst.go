@@ -0,0 +1,167 @@
+package lintdsl
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"honnef.co/go/tools/go/ssa"
+	"honnef.co/go/tools/go/token"
+	"honnef.co/go/tools/go/types"
+)
+
+// RepeatOpts controls the behaviour of FindRepeatedConsts.
+type RepeatOpts struct {
+	// MinLength is the minimum length, in bytes, a literal's
+	// normalized value must have to be considered. Shorter literals (e.g. "",
+	// "0") are almost never worth naming.
+	MinLength int
+	// MinOccurrences is the minimum number of times a literal's
+	// value must occur in the package before it is reported.
+	MinOccurrences int
+	// IgnoreConstDecls, when true, skips literals that already
+	// appear inside a const declaration.
+	IgnoreConstDecls bool
+	// IgnoreTests, when true, skips literals found in _test.go
+	// files.
+	IgnoreTests bool
+	// Exclude, if non-nil, is matched against each literal's
+	// normalized value (strings unquoted, numbers in canonical
+	// decimal form, as normalizeLit returns it); matches are skipped.
+	Exclude *regexp.Regexp
+}
+
+// A RepeatGroup describes one literal value that occurs often enough
+// in a package to be worth naming as a constant.
+type RepeatGroup struct {
+	Value     string
+	Kind      token.Token
+	Positions []token.Pos
+}
+
+// FindRepeatedConsts walks pkg looking for string, integer, and
+// floating-point literals that occur at least opts.MinOccurrences
+// times, returning one RepeatGroup per distinct value. It is the
+// basis for goconst-style checks: literals that are repeated often
+// enough usually deserve a named constant instead.
+func FindRepeatedConsts(pkg *ssa.Package, opts RepeatOpts) []RepeatGroup {
+	type key struct {
+		kind  token.Token
+		value string
+	}
+	groups := map[key]*RepeatGroup{}
+
+	constDecls := map[types.Node]bool{}
+	if opts.IgnoreConstDecls {
+		for _, f := range pkg.Files {
+			for node := range collectConstDecls(f) {
+				constDecls[node] = true
+			}
+		}
+	}
+
+	for _, f := range pkg.Files {
+		if opts.IgnoreTests && isTestFile(pkg, f) {
+			continue
+		}
+
+		Inspect(f, func(node types.Node) bool {
+			lit, ok := node.(*types.BasicLit)
+			if !ok {
+				return true
+			}
+			if lit.Kind != token.STRING && lit.Kind != token.INT && lit.Kind != token.FLOAT {
+				return true
+			}
+			if opts.IgnoreConstDecls && constDecls[lit] {
+				return true
+			}
+
+			value, ok := normalizeLit(lit)
+			if !ok {
+				return true
+			}
+			if len(value) < opts.MinLength {
+				return true
+			}
+			if opts.Exclude != nil && opts.Exclude.MatchString(value) {
+				return true
+			}
+
+			k := key{lit.Kind, value}
+			g, ok := groups[k]
+			if !ok {
+				g = &RepeatGroup{Value: value, Kind: lit.Kind}
+				groups[k] = g
+			}
+			g.Positions = append(g.Positions, lit.Pos())
+			return true
+		})
+	}
+
+	var out []RepeatGroup
+	for _, g := range groups {
+		if len(g.Positions) >= opts.MinOccurrences {
+			out = append(out, *g)
+		}
+	}
+	return out
+}
+
+// normalizeLit returns the literal's value with string quoting
+// stripped and numeric bases folded to a canonical decimal form, so
+// that e.g. 0x10 and 16 are recognized as the same repeated value.
+func normalizeLit(lit *types.BasicLit) (string, bool) {
+	switch lit.Kind {
+	case token.STRING:
+		s, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return "", false
+		}
+		return s, true
+	case token.INT:
+		n, err := strconv.ParseInt(lit.Value, 0, 64)
+		if err != nil {
+			return "", false
+		}
+		return strconv.FormatInt(n, 10), true
+	case token.FLOAT:
+		n, err := strconv.ParseFloat(lit.Value, 64)
+		if err != nil {
+			return "", false
+		}
+		return strconv.FormatFloat(n, 'g', -1, 64), true
+	}
+	return "", false
+}
+
+func isTestFile(pkg *ssa.Package, f *types.File) bool {
+	tf := pkg.Prog.Fset.File(f.Pos())
+	return tf != nil && strings.HasSuffix(tf.Name(), "_test.go")
+}
+
+// IsInConstDecl reports whether node lies within a const declaration
+// in f.
+func IsInConstDecl(node types.Node, f *types.File) bool {
+	return collectConstDecls(f)[node]
+}
+
+// collectConstDecls walks f once and returns the set of every node
+// nested inside a "const ( ... )" declaration, so that callers can
+// test membership without re-walking the file per node.
+func collectConstDecls(f *types.File) map[types.Node]bool {
+	in := map[types.Node]bool{}
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*types.GenDecl)
+		if !ok || gd.Tok != token.CONST {
+			continue
+		}
+		Inspect(gd, func(node types.Node) bool {
+			if node != nil {
+				in[node] = true
+			}
+			return true
+		})
+	}
+	return in
+}
@@ -0,0 +1,66 @@
+package lintdsl
+
+import (
+	"testing"
+
+	"honnef.co/go/tools/go/token"
+	"honnef.co/go/tools/go/types"
+)
+
+func TestNormalizeLit(t *testing.T) {
+	tests := []struct {
+		kind  token.Token
+		value string
+		want  string
+		ok    bool
+	}{
+		{token.STRING, `"foo"`, "foo", true},
+		{token.STRING, "`bar`", "bar", true},
+		{token.STRING, `"unterminated`, "", false},
+		{token.INT, "16", "16", true},
+		{token.INT, "0x10", "16", true},
+		{token.INT, "not a number", "", false},
+		{token.FLOAT, "1.5", "1.5", true},
+		{token.FLOAT, "1.50", "1.5", true},
+	}
+	for _, tt := range tests {
+		got, ok := normalizeLit(&types.BasicLit{Kind: tt.kind, Value: tt.value})
+		if ok != tt.ok {
+			t.Errorf("normalizeLit(%q): ok = %v, want %v", tt.value, ok, tt.ok)
+			continue
+		}
+		if ok && got != tt.want {
+			t.Errorf("normalizeLit(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestCollectConstDecls(t *testing.T) {
+	in := &types.Ident{Name: "inConst"}
+	out := &types.Ident{Name: "notInConst"}
+
+	f := &types.File{
+		Decls: []types.Decl{
+			&types.GenDecl{
+				Tok: token.CONST,
+				Specs: []types.Spec{
+					&types.ValueSpec{Names: []*types.Ident{in}},
+				},
+			},
+			&types.GenDecl{
+				Tok: token.VAR,
+				Specs: []types.Spec{
+					&types.ValueSpec{Names: []*types.Ident{out}},
+				},
+			},
+		},
+	}
+
+	got := collectConstDecls(f)
+	if !got[in] {
+		t.Error("collectConstDecls: identifier in a const decl not in result")
+	}
+	if got[out] {
+		t.Error("collectConstDecls: identifier in a var decl wrongly in result")
+	}
+}
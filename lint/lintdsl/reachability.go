@@ -0,0 +1,44 @@
+package lintdsl
+
+import (
+	"honnef.co/go/tools/go/callgraph"
+	"honnef.co/go/tools/go/ssa"
+	"honnef.co/go/tools/ssa/ssautil"
+)
+
+// IsReachableFromMain reports whether fn may be reachable, according
+// to a CHA call graph of fn.Prog, from some package's init function
+// or from main.main. It is conservative: it may report true for
+// functions that can never actually execute, but it will not report
+// false for one that can.
+//
+// Building the call graph is not free; callers that need to ask this
+// question for many functions in the same program should build it
+// once with ssautil.CallGraph and walk it themselves instead of
+// calling IsReachableFromMain repeatedly.
+func IsReachableFromMain(fn *ssa.Function) bool {
+	cg := ssautil.CallGraph(fn.Prog)
+	n, ok := cg.Nodes[fn]
+	if !ok {
+		return false
+	}
+
+	seen := make(map[*callgraph.Node]bool)
+	var reachable func(n *callgraph.Node) bool
+	reachable = func(cur *callgraph.Node) bool {
+		if cur == n {
+			return true
+		}
+		if seen[cur] {
+			return false
+		}
+		seen[cur] = true
+		for _, e := range cur.Out {
+			if reachable(e.Callee) {
+				return true
+			}
+		}
+		return false
+	}
+	return reachable(cg.Root)
+}
@@ -0,0 +1,54 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssautil
+
+import (
+	"honnef.co/go/tools/go/callgraph"
+	"honnef.co/go/tools/go/callgraph/cha"
+	"honnef.co/go/tools/go/ssa"
+)
+
+// chaConfig holds the options accumulated from a list of CHAOption
+// values.
+type chaConfig struct {
+	deleteSynthetic bool
+}
+
+// A CHAOption configures the call graph produced by CallGraph.
+type CHAOption func(*chaConfig)
+
+// WithoutSyntheticNodes causes CallGraph to strip synthetic wrappers
+// (bound method thunks, init wrappers, and the like) from the
+// resulting graph, splicing their edges so that callers connect
+// directly to the wrapped function.
+func WithoutSyntheticNodes() CHAOption {
+	return func(c *chaConfig) { c.deleteSynthetic = true }
+}
+
+// CallGraph computes a whole-program call graph for prog using Class
+// Hierarchy Analysis (CHA). For a static call it adds a single edge
+// to the callee; for an interface invocation it adds an edge to
+// every method, across every concrete type discovered in
+// prog.RuntimeTypes(), that could satisfy the call; for a dynamic
+// call of a function value it conservatively adds an edge to every
+// address-taken function with a matching signature.
+//
+// The analysis is unsound in the face of reflection and unsafe, and
+// it is not reachability-aware: it may report call edges that can
+// never execute. It is, however, cheap and suitable for lintdsl
+// checks that only need a conservative over-approximation, such as
+// IsReachableFromMain.
+func CallGraph(prog *ssa.Program, opts ...CHAOption) *callgraph.Graph {
+	var cfg chaConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	cg := cha.CallGraph(prog)
+	if cfg.deleteSynthetic {
+		callgraph.DeleteSyntheticNodes(cg)
+	}
+	return cg
+}
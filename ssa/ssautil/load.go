@@ -7,6 +7,7 @@ package ssautil
 // This file defines utility functions for constructing programs in SSA form.
 
 import (
+	"go/parser"
 	"go/token"
 
 	"honnef.co/go/tools/go/packages"
@@ -77,6 +78,107 @@ func Packages(initial []*packages.Package, mode ssa.BuilderMode) (*ssa.Program,
 // See ../ssa/example_test.go for an example.
 //
 func BuildPackage(tc *types.Config, fset *token.FileSet, pkg *types.Package, files []*types.File, mode ssa.BuilderMode) (*ssa.Package, *types.Info, error) {
+	return buildPackage(tc, fset, pkg, files, mode)
+}
+
+// overlayImporter is implemented by a types.Importer that can be told
+// about in-memory overlay files, such as a source-mode importer built
+// on golang.org/x/tools/go/loader. BuildPackageOverlay threads its
+// overlay through to tc.Importer when it implements this interface,
+// so that transitive source-mode imports see the same modified files
+// as the primary package.
+type overlayImporter interface {
+	types.Importer
+	SetOverlay(overlay map[string][]byte)
+}
+
+// BuildOverlayConfig configures how BuildPackageOverlay treats parse
+// errors in overlay files.
+type BuildOverlayConfig struct {
+	// AllowPartial, when true, lets a parse error in one overlay file
+	// degrade gracefully: the offending file is dropped, building
+	// proceeds with the rest, and the first parse error encountered
+	// is returned alongside a non-nil, partial *ssa.Package. When
+	// false (the default), a parse error in any overlay file aborts
+	// the build, matching BuildPackage's existing all-or-nothing
+	// behaviour for type-checking errors. Dropping a file does not
+	// relax type checking: if the remaining files fail to type-check
+	// (for instance because they use something the dropped file
+	// declared), the build fails with a nil *ssa.Package either way.
+	AllowPartial bool
+}
+
+// BuildPackageOverlay is like BuildPackage, but any path in paths
+// that is also a key of overlay is parsed from the supplied bytes
+// instead of being read from disk. fset positions for overlay files
+// refer to the given path, not to whatever (if anything) is on disk
+// there. If tc.Importer implements overlayImporter, the overlay is
+// passed to it for the duration of this call only: it is cleared
+// again before BuildPackageOverlay returns, so a long-lived importer
+// reused across calls (e.g. by a gopls-style driver juggling several
+// packages' unsaved buffers) never retains a previous call's overlay.
+//
+// tc.Importer is mutated for the duration of the call, so
+// BuildPackageOverlay is not safe to call concurrently with the same
+// tc.Importer from multiple goroutines; callers that need to build
+// overlays concurrently must either serialize their calls or give
+// each one its own *types.Config with its own Importer.
+func BuildPackageOverlay(tc *types.Config, fset *token.FileSet, pkg *types.Package, paths []string, overlay map[string][]byte, mode ssa.BuilderMode, cfg BuildOverlayConfig) (*ssa.Package, *types.Info, error) {
+	if imp, ok := tc.Importer.(overlayImporter); ok {
+		imp.SetOverlay(overlay)
+		defer imp.SetOverlay(nil)
+	}
+
+	files, parseErr := parseOverlayFiles(fset, paths, overlay, cfg.AllowPartial)
+	if parseErr != nil && !cfg.AllowPartial {
+		return nil, nil, parseErr
+	}
+
+	ssapkg, info, err := buildPackage(tc, fset, pkg, files, mode)
+	if err != nil {
+		return nil, nil, err
+	}
+	if parseErr != nil {
+		// cfg.AllowPartial: report the parse error alongside the
+		// partial package built from whichever files did parse.
+		return ssapkg, info, parseErr
+	}
+	return ssapkg, info, nil
+}
+
+// parseOverlayFiles parses each of paths, preferring overlay's bytes
+// over disk when a path is present in it. If allowPartial is true, a
+// file that fails to parse is skipped rather than aborting the whole
+// operation, and the first such error is returned alongside the
+// files that did parse.
+func parseOverlayFiles(fset *token.FileSet, paths []string, overlay map[string][]byte, allowPartial bool) ([]*types.File, error) {
+	files := make([]*types.File, 0, len(paths))
+	var firstErr error
+	for _, path := range paths {
+		// ParseFile takes src as an interface{}; a typed nil []byte
+		// (the zero value of the map lookup's first result) isn't
+		// the same as an untyped nil and would make it parse an
+		// empty overlay file instead of falling back to disk.
+		var src interface{}
+		if data, ok := overlay[path]; ok {
+			src = data
+		}
+		f, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+		if err != nil {
+			if !allowPartial {
+				return nil, err
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		files = append(files, f)
+	}
+	return files, firstErr
+}
+
+func buildPackage(tc *types.Config, fset *token.FileSet, pkg *types.Package, files []*types.File, mode ssa.BuilderMode) (*ssa.Package, *types.Info, error) {
 	if fset == nil {
 		panic("no token.FileSet")
 	}